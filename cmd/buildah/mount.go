@@ -3,17 +3,47 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
 
+	"github.com/containers/buildah"
 	buildahcli "github.com/containers/buildah/pkg/cli"
+	"github.com/containers/buildah/pkg/unshare"
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/mount"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
 )
 
+// mountReexecFDEnv carries the file descriptor number of the pipe the
+// re-executed, userns-confined child should use to report its JSON output
+// back to the parent process.
+const mountReexecFDEnv = "_BUILDAH_MOUNT_REEXEC_FD"
+
 type jsonMount struct {
-	Container  string `json:"container,omitempty"`
+	ID         string       `json:"id,omitempty"`
+	Container  string       `json:"container,omitempty"`
+	MountPoint string       `json:"mountPoint"`
+	Driver     string       `json:"driver"`
+	Rootless   bool         `json:"rootless"`
+	MountLabel string       `json:"mountLabel"`
+	UpperDir   string       `json:"upperDir,omitempty"`
+	Layers     []LayerMount `json:"layers,omitempty"`
+}
+
+// LayerMount describes one parent image layer mounted on behalf of a
+// --recursive "buildah mount".
+type LayerMount struct {
+	LayerID    string `json:"layerID"`
 	MountPoint string `json:"mountPoint"`
+	Parent     string `json:"parent,omitempty"`
 }
 
 func init() {
@@ -25,15 +55,30 @@ func init() {
   into the usernamespace. Afterwards you can buildah mount the container and
   view/modify the content in the containers root file system.
 `
-		noTruncate bool
-		outputJSON bool
+		noTruncate          bool
+		outputJSON          bool
+		format              string
+		mountMode           string
+		propagation         string
+		rootlessAutoUnshare bool
+		overlayFlag         bool
+		overlayUpperDir     string
+		overlayWorkDir      string
+		discardChanges      bool
+		recursive           bool
 	)
 	mountCommand := &cobra.Command{
 		Use:   "mount",
 		Short: "Mount a working container's root filesystem",
 		Long:  mountDescription,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return mountCmd(cmd, args, outputJSON)
+			opts := overlayOptions{
+				enabled:        overlayFlag,
+				upperDir:       overlayUpperDir,
+				workDir:        overlayWorkDir,
+				discardChanges: discardChanges,
+			}
+			return mountCmd(cmd, args, outputJSON, format, mountMode, propagation, rootlessAutoUnshare, opts, recursive)
 		},
 		Example: `buildah mount
   buildah mount containerID
@@ -49,23 +94,303 @@ func init() {
 	flags := mountCommand.Flags()
 	flags.SetInterspersed(false)
 	flags.BoolVar(&outputJSON, "json", false, "output in JSON format")
+	flags.StringVar(&format, "format", "", "pretty-print mounts using a Go template")
+	// noTruncate is kept for CLI parity with buildah's other list commands,
+	// but unlike those commands, mount has no truncated-ID column to begin
+	// with: the human output never prints an ID, and the ID exposed to
+	// --format/--json is always the full container ID. There is nothing for
+	// --notruncate (or --format, which would need the same full ID) to
+	// disable, so this flag is intentionally a no-op here.
 	flags.BoolVar(&noTruncate, "notruncate", false, "do not truncate output")
+	flags.StringVar(&mountMode, "mode", "rw", "mount mode to use for the container's root filesystem (ro or rw)")
+	flags.StringVar(&propagation, "propagation", "", "mount propagation for the mountpoint (private, rslave, rshared)")
+	flags.BoolVar(&rootlessAutoUnshare, "rootless-auto-unshare", true, "automatically re-exec inside a `buildah unshare` session when mounting rootlessly with a non-vfs driver")
+	flags.BoolVar(&overlayFlag, "overlay", false, "mount a writable overlay on top of the container's rootfs instead of mounting it directly")
+	flags.StringVar(&overlayUpperDir, "overlay-upperdir", "", "directory to use as the overlay upperdir (default: auto-generated)")
+	flags.StringVar(&overlayWorkDir, "overlay-workdir", "", "directory to use as the overlay workdir (default: auto-generated)")
+	flags.BoolVar(&discardChanges, "discard-changes", false, "discard the overlay upperdir on `buildah umount` instead of leaving it for inspection")
+	flags.BoolVar(&recursive, "recursive", false, "also mount every parent image layer, reporting them together as a tree")
+	flags.BoolVar(&recursive, "with-image", false, "alias for --recursive")
 	rootCmd.AddCommand(mountCommand)
 	if err := flags.MarkHidden("notruncate"); err != nil {
 		logrus.Fatalf("error marking notruncate as hidden: %v", err)
 	}
+	if err := flags.MarkHidden("with-image"); err != nil {
+		logrus.Fatalf("error marking with-image as hidden: %v", err)
+	}
+}
+
+// remountReadOnly bind-mounts mountPoint onto itself and then remounts it
+// read-only, so that callers who only want to inspect a container's rootfs
+// cannot accidentally (or intentionally) modify it.
+func remountReadOnly(mountPoint string) error {
+	if err := unix.Mount(mountPoint, mountPoint, "", unix.MS_BIND, ""); err != nil {
+		return errors.Wrapf(err, "error bind mounting %q", mountPoint)
+	}
+	if err := unix.Mount("", mountPoint, "", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_RDONLY, ""); err != nil {
+		return errors.Wrapf(err, "error remounting %q read-only", mountPoint)
+	}
+	return nil
+}
+
+// validMountMode reports whether mode is one of the modes accepted by
+// --mode.
+func validMountMode(mode string) error {
+	switch mode {
+	case "ro", "rw":
+		return nil
+	default:
+		return errors.Errorf("invalid mode %q: must be one of ro, rw", mode)
+	}
+}
+
+// setMountPropagation applies the requested mount propagation to mountPoint
+// using the same "mount --make-*" semantics the storage library relies on
+// elsewhere.
+func setMountPropagation(mountPoint, propagation string) error {
+	switch propagation {
+	case "private":
+		return mount.MakePrivate(mountPoint)
+	case "rslave":
+		return mount.MakeRSlave(mountPoint)
+	case "rshared":
+		return mount.MakeRShared(mountPoint)
+	default:
+		return errors.Errorf("invalid propagation %q: must be one of private, rslave, rshared", propagation)
+	}
+}
+
+// overlayOptions collects the --overlay family of flags.
+type overlayOptions struct {
+	enabled        bool
+	upperDir       string
+	workDir        string
+	discardChanges bool
+}
+
+// setupOverlay layers a writable overlay on top of lowerDir, auto-generating
+// an upperdir/workdir pair under the system temporary directory when the
+// caller didn't supply one, and returns the merged mountpoint, the upperdir,
+// and the workdir so that callers can diff or export just the changes made
+// during inspection, and so "buildah umount" can tear the overlay back down.
+func setupOverlay(lowerDir string, opts overlayOptions) (mergedDir, upperDir, workDir string, err error) {
+	upperDir = opts.upperDir
+	if upperDir == "" {
+		if upperDir, err = ioutil.TempDir("", "buildah-mount-upper"); err != nil {
+			return "", "", "", errors.Wrapf(err, "error creating overlay upperdir")
+		}
+	} else if err = os.MkdirAll(upperDir, 0o755); err != nil {
+		return "", "", "", errors.Wrapf(err, "error creating overlay upperdir %q", upperDir)
+	}
+	workDir = opts.workDir
+	if workDir == "" {
+		if workDir, err = ioutil.TempDir("", "buildah-mount-work"); err != nil {
+			return "", "", "", errors.Wrapf(err, "error creating overlay workdir")
+		}
+	} else if err = os.MkdirAll(workDir, 0o755); err != nil {
+		return "", "", "", errors.Wrapf(err, "error creating overlay workdir %q", workDir)
+	}
+	mergedDir, err = ioutil.TempDir("", "buildah-mount-merged")
+	if err != nil {
+		return "", "", "", errors.Wrapf(err, "error creating overlay mountpoint")
+	}
+	options := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", lowerDir, upperDir, workDir)
+	if err := unix.Mount("overlay", mergedDir, "overlay", 0, options); err != nil {
+		return "", "", "", errors.Wrapf(err, "error mounting overlay at %q", mergedDir)
+	}
+	return mergedDir, upperDir, workDir, nil
+}
+
+// mountImageLayers mounts topLayerID and every one of its parent layers,
+// walking the chain with store.Layer and mounting each with store.Mount, and
+// reports them together as a tree. If any layer fails to mount, everything
+// mounted so far is torn down again before the error is returned.
+func mountImageLayers(store storage.Store, topLayerID string) ([]LayerMount, error) {
+	var layerMounts []LayerMount
+	for id := topLayerID; id != ""; {
+		layer, err := store.Layer(id)
+		if err != nil {
+			unmountLayerMounts(store, layerMounts)
+			return nil, errors.Wrapf(err, "error reading layer %q", id)
+		}
+		mountPoint, err := store.Mount(id, "")
+		if err != nil {
+			unmountLayerMounts(store, layerMounts)
+			return nil, errors.Wrapf(err, "error mounting layer %q", id)
+		}
+		layerMounts = append(layerMounts, LayerMount{LayerID: id, MountPoint: mountPoint, Parent: layer.Parent})
+		id = layer.Parent
+	}
+	return layerMounts, nil
+}
+
+// unmountLayerMounts tears down every layer mounted by mountImageLayers,
+// logging rather than failing on individual unmount errors so that one
+// stuck layer doesn't stop the rest from being cleaned up.
+func unmountLayerMounts(store storage.Store, layerMounts []LayerMount) {
+	for _, l := range layerMounts {
+		if _, err := store.Unmount(l.LayerID, false); err != nil {
+			logrus.Debugf("error unmounting layer %q: %v", l.LayerID, err)
+		}
+	}
+}
+
+// teardownMountExtras reverses the extra mounts "buildah mount" stacks on
+// top of a container's graph-driver mountpoint (the read-only bind-remount
+// from --mode=ro, the overlay from --overlay, and the parent image layers
+// from --recursive), based on the annotations mountCmd recorded. It must be
+// called before builder.Unmount(), or the graph driver's own unmount can
+// fail EBUSY or silently leave those extra mounts behind.
+func teardownMountExtras(store storage.Store, builder *buildah.Builder) error {
+	annotations := builder.Annotations()
+	mode := annotations["buildah.mount-mode"]
+	propagation := annotations["buildah.mount-propagation"]
+	overlayMergedDir := annotations["buildah.overlay-merged-dir"]
+	layerIDs := annotations["buildah.mount-layers"]
+
+	if mode == "" && propagation == "" && overlayMergedDir == "" && layerIDs == "" {
+		return nil
+	}
+
+	finalMountPoint := builder.MountPoint
+	if overlayMergedDir != "" {
+		finalMountPoint = overlayMergedDir
+	}
+	if mode == "ro" {
+		if err := unix.Unmount(finalMountPoint, 0); err != nil {
+			return errors.Wrapf(err, "error undoing read-only mount at %q", finalMountPoint)
+		}
+	}
+
+	if overlayMergedDir != "" {
+		if err := unix.Unmount(overlayMergedDir, 0); err != nil {
+			return errors.Wrapf(err, "error unmounting overlay at %q", overlayMergedDir)
+		}
+		if err := os.RemoveAll(overlayMergedDir); err != nil {
+			return errors.Wrapf(err, "error removing overlay mountpoint %q", overlayMergedDir)
+		}
+		if discard, _ := strconv.ParseBool(annotations["buildah.overlay-discard-changes"]); discard {
+			if upperDir := annotations["buildah.overlay-upperdir"]; upperDir != "" {
+				if err := os.RemoveAll(upperDir); err != nil {
+					return errors.Wrapf(err, "error discarding overlay upperdir %q", upperDir)
+				}
+			}
+			if workDir := annotations["buildah.overlay-workdir"]; workDir != "" {
+				if err := os.RemoveAll(workDir); err != nil {
+					return errors.Wrapf(err, "error discarding overlay workdir %q", workDir)
+				}
+			}
+		}
+		builder.SetAnnotation("buildah.overlay-merged-dir", "")
+		builder.SetAnnotation("buildah.overlay-upperdir", "")
+		builder.SetAnnotation("buildah.overlay-workdir", "")
+		builder.SetAnnotation("buildah.overlay-discard-changes", "")
+	}
+
+	if layerIDs != "" {
+		for _, id := range strings.Split(layerIDs, ",") {
+			if _, err := store.Unmount(id, false); err != nil {
+				logrus.Debugf("error unmounting layer %q: %v", id, err)
+			}
+		}
+		builder.SetAnnotation("buildah.mount-layers", "")
+	}
+
+	builder.SetAnnotation("buildah.mount-mode", "")
+	builder.SetAnnotation("buildah.mount-propagation", "")
+	return builder.Save()
+}
+
+// unmountBuilder tears down the extra mounts recorded for builder and then
+// unmounts its graph-driver mountpoint, for use by "buildah umount".
+func unmountBuilder(store storage.Store, builder *buildah.Builder) error {
+	if err := teardownMountExtras(store, builder); err != nil {
+		return err
+	}
+	if err := builder.Unmount(); err != nil {
+		return errors.Wrapf(err, "error unmounting container %q", builder.Container)
+	}
+	return nil
+}
+
+// reexecMountInUserNS re-execs the current process inside a user namespace,
+// equivalent to running "buildah unshare -- buildah mount ...", and forwards
+// its stdio and exit code back to the caller. When outputJSON is set, the
+// child reports its JSON output over a pipe instead of its own stdout, and
+// the parent relays it to its own stdout while the child runs.
+func reexecMountInUserNS(outputJSON bool) error {
+	pipeR, pipeW, err := os.Pipe()
+	if err != nil {
+		return errors.Wrapf(err, "error creating pipe for userns mount child")
+	}
+	defer pipeR.Close()
+
+	// os.Args[0] is the buildah executable itself; unshare.Command needs the
+	// full argv (executable plus "mount" plus its args), not just the args,
+	// or the child ends up invoking the root command instead of "mount".
+	cmd := unshare.Command(os.Args...)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=3", mountReexecFDEnv))
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	if outputJSON {
+		cmd.Stdout = os.Stderr
+	} else {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.ExtraFiles = []*os.File{pipeW}
+
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "error starting userns mount child")
+	}
+	pipeW.Close()
+
+	// Drain the pipe concurrently with Wait(): the child's JSON output can
+	// exceed the OS pipe buffer, and it won't exit until that write
+	// unblocks, so waiting for it to exit before reading would deadlock.
+	var copyErr error
+	copyDone := make(chan struct{})
+	go func() {
+		if outputJSON {
+			_, copyErr = io.Copy(os.Stdout, pipeR)
+		} else {
+			io.Copy(ioutil.Discard, pipeR)
+		}
+		close(copyDone)
+	}()
+
+	runErr := cmd.Wait()
+	<-copyDone
+	if runErr == nil && copyErr != nil {
+		runErr = errors.Wrapf(copyErr, "error relaying JSON output from userns mount child")
+	}
+	if exitError, ok := runErr.(*exec.ExitError); ok {
+		os.Exit(exitError.ExitCode())
+	}
+	return runErr
 }
 
-func mountCmd(c *cobra.Command, args []string, outputJSON bool) error {
+func mountCmd(c *cobra.Command, args []string, outputJSON bool, format, mountMode, propagation string, rootlessAutoUnshare bool, overlay overlayOptions, recursive bool) error {
 
 	if err := buildahcli.VerifyFlagsArgsOrder(args); err != nil {
 		return err
 	}
+	if err := validMountMode(mountMode); err != nil {
+		return err
+	}
 
 	store, err := getStore(c)
 	if err != nil {
 		return err
 	}
+	// A format template needs the same structured data as --json, so we
+	// collect it whenever either is requested.
+	collectStructured := outputJSON || format != ""
+	// os.Geteuid() reports 0 once we're inside the user namespace that
+	// reexecMountInUserNS re-execs into, even though the invocation was
+	// rootless from the caller's perspective - that's the whole reason we
+	// re-exec in the first place. mountReexecFDEnv is only ever set in that
+	// child, so its presence is what actually tells us this was rootless.
+	rootless := os.Geteuid() != 0 || os.Getenv(mountReexecFDEnv) != ""
 	var jsonMounts []jsonMount
 	var lastError error
 	if len(args) > 0 {
@@ -74,7 +399,10 @@ func mountCmd(c *cobra.Command, args []string, outputJSON bool) error {
 		// Differently, allow the mount if we are already in a userns, as the mount point will still
 		// be accessible once "buildah mount" exits.
 		if os.Geteuid() != 0 && store.GraphDriverName() != "vfs" {
-			return errors.Errorf("cannot mount using driver %s in rootless mode. You need to run it in a `buildah unshare` session", store.GraphDriverName())
+			if !rootlessAutoUnshare {
+				return errors.Errorf("cannot mount using driver %s in rootless mode. You need to run it in a `buildah unshare` session", store.GraphDriverName())
+			}
+			return reexecMountInUserNS(outputJSON)
 		}
 
 		for _, name := range args {
@@ -94,18 +422,126 @@ func mountCmd(c *cobra.Command, args []string, outputJSON bool) error {
 				lastError = errors.Wrapf(err, "error mounting %q container %q", name, builder.Container)
 				continue
 			}
+			var upperDir string
+			if overlay.enabled {
+				mergedDir, dir, workDir, err := setupOverlay(mountPoint, overlay)
+				if err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = err
+					continue
+				}
+				mountPoint, upperDir = mergedDir, dir
+				builder.SetAnnotation("buildah.overlay-merged-dir", mountPoint)
+				builder.SetAnnotation("buildah.overlay-upperdir", upperDir)
+				builder.SetAnnotation("buildah.overlay-workdir", workDir)
+				builder.SetAnnotation("buildah.overlay-discard-changes", fmt.Sprintf("%t", overlay.discardChanges))
+			}
+			if mountMode == "ro" {
+				if err := remountReadOnly(mountPoint); err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = err
+					continue
+				}
+				// Recorded immediately, not deferred to the bottom of the
+				// loop, so that a later failure in this same iteration
+				// tears this mount back down instead of leaking it.
+				builder.SetAnnotation("buildah.mount-mode", mountMode)
+			}
+			if propagation != "" {
+				if err := setMountPropagation(mountPoint, propagation); err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = err
+					unmountBuilder(store, builder)
+					continue
+				}
+				builder.SetAnnotation("buildah.mount-propagation", propagation)
+			}
+			var layerMounts []LayerMount
+			if recursive {
+				cont, err := store.Container(builder.ContainerID)
+				if err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = errors.Wrapf(err, "error reading container %q", name)
+					unmountBuilder(store, builder)
+					continue
+				}
+				containerLayer, err := store.Layer(cont.LayerID)
+				if err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = errors.Wrapf(err, "error reading layer %q", cont.LayerID)
+					unmountBuilder(store, builder)
+					continue
+				}
+				layerMounts, err = mountImageLayers(store, containerLayer.Parent)
+				if err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = err
+					unmountBuilder(store, builder)
+					continue
+				}
+				// Recorded immediately so that a failure further down this
+				// same iteration still unwinds these via teardownMountExtras
+				// instead of leaking them with their store refcounts held.
+				layerIDs := make([]string, len(layerMounts))
+				for i, l := range layerMounts {
+					layerIDs[i] = l.LayerID
+				}
+				builder.SetAnnotation("buildah.mount-layers", strings.Join(layerIDs, ","))
+			}
+			// Only persist state when something other than the plain
+			// default mount was actually requested: a plain "buildah mount
+			// ctr" shouldn't turn into a write that can now fail where it
+			// previously couldn't. mode/propagation annotations were
+			// already recorded above, as soon as each was applied.
+			if mountMode != "rw" || propagation != "" || overlay.enabled || recursive {
+				if err := builder.Save(); err != nil {
+					if lastError != nil {
+						fmt.Fprintln(os.Stderr, lastError)
+					}
+					lastError = errors.Wrapf(err, "error saving state for container %q", name)
+					// unmountBuilder -> teardownMountExtras already unmounts
+					// every ID in the buildah.mount-layers annotation set
+					// above; unmounting layerMounts here too would decrement
+					// each layer's store refcount a second time and could
+					// pull a layer out from under another container using it.
+					unmountBuilder(store, builder)
+					continue
+				}
+			}
 			if len(args) > 1 {
-				if outputJSON {
-					jsonMounts = append(jsonMounts, jsonMount{Container: name, MountPoint: mountPoint})
+				if collectStructured {
+					jsonMounts = append(jsonMounts, jsonMount{ID: builder.ContainerID, Container: name, MountPoint: mountPoint, Driver: store.GraphDriverName(), Rootless: rootless, MountLabel: builder.MountLabel, UpperDir: upperDir, Layers: layerMounts})
 					continue
 				}
-				fmt.Printf("%s %s\n", name, mountPoint)
+				if overlay.enabled {
+					fmt.Printf("%s %s %s\n", name, mountPoint, upperDir)
+				} else {
+					fmt.Printf("%s %s\n", name, mountPoint)
+				}
+				printLayerMounts(layerMounts)
 			} else {
-				if outputJSON {
-					jsonMounts = append(jsonMounts, jsonMount{MountPoint: mountPoint})
+				if collectStructured {
+					jsonMounts = append(jsonMounts, jsonMount{ID: builder.ContainerID, MountPoint: mountPoint, Driver: store.GraphDriverName(), Rootless: rootless, MountLabel: builder.MountLabel, UpperDir: upperDir, Layers: layerMounts})
 					continue
 				}
-				fmt.Printf("%s\n", mountPoint)
+				if overlay.enabled {
+					fmt.Printf("%s %s\n", mountPoint, upperDir)
+				} else {
+					fmt.Printf("%s\n", mountPoint)
+				}
+				printLayerMounts(layerMounts)
 			}
 		}
 	} else {
@@ -120,8 +556,8 @@ func mountCmd(c *cobra.Command, args []string, outputJSON bool) error {
 				return err
 			}
 			if mounted {
-				if outputJSON {
-					jsonMounts = append(jsonMounts, jsonMount{Container: builder.Container, MountPoint: builder.MountPoint})
+				if collectStructured {
+					jsonMounts = append(jsonMounts, jsonMount{ID: builder.ContainerID, Container: builder.Container, MountPoint: builder.MountPoint, Driver: store.GraphDriverName(), Rootless: rootless, MountLabel: builder.MountLabel})
 					continue
 				}
 				fmt.Printf("%s %s\n", builder.Container, builder.MountPoint)
@@ -129,13 +565,50 @@ func mountCmd(c *cobra.Command, args []string, outputJSON bool) error {
 		}
 	}
 
-	if outputJSON {
+	switch {
+	case format != "":
+		if err := outputMountsWithTemplate(os.Stdout, jsonMounts, format); err != nil {
+			return err
+		}
+	case outputJSON:
 		data, err := json.MarshalIndent(jsonMounts, "", "    ")
 		if err != nil {
 			return err
 		}
+		if fdStr := os.Getenv(mountReexecFDEnv); fdStr != "" {
+			if fd, convErr := strconv.Atoi(fdStr); convErr == nil {
+				pipe := os.NewFile(uintptr(fd), "mount-json-pipe")
+				fmt.Fprintf(pipe, "%s\n", data)
+				pipe.Close()
+				return lastError
+			}
+		}
 		fmt.Printf("%s\n", data)
 	}
 
 	return lastError
 }
+
+// printLayerMounts prints the parent image layers mounted for --recursive,
+// indented under the container's own mountpoint line.
+func printLayerMounts(layerMounts []LayerMount) {
+	for _, l := range layerMounts {
+		fmt.Printf("  %s %s\n", l.LayerID, l.MountPoint)
+	}
+}
+
+// outputMountsWithTemplate renders each mount through the given Go template,
+// one mount per line, the way "podman ps --format" does.
+func outputMountsWithTemplate(w io.Writer, mounts []jsonMount, format string) error {
+	tmpl, err := template.New("mount").Parse(format)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing format template %q", format)
+	}
+	for _, m := range mounts {
+		if err := tmpl.Execute(w, m); err != nil {
+			return errors.Wrapf(err, "error executing format template")
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
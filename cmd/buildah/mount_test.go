@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOutputMountsWithTemplate(t *testing.T) {
+	mounts := []jsonMount{
+		{ID: "abc123", Container: "ctr1", MountPoint: "/var/lib/containers/storage/overlay/abc123/merged"},
+		{ID: "def456", MountPoint: "/var/lib/containers/storage/overlay/def456/merged"},
+	}
+	var buf bytes.Buffer
+	if err := outputMountsWithTemplate(&buf, mounts, "{{.ID}} {{.MountPoint}}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "abc123 /var/lib/containers/storage/overlay/abc123/merged\n" +
+		"def456 /var/lib/containers/storage/overlay/def456/merged\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutputMountsWithTemplateBadField(t *testing.T) {
+	var buf bytes.Buffer
+	err := outputMountsWithTemplate(&buf, []jsonMount{{MountPoint: "/mnt"}}, "{{.NoSuchField}}")
+	if err == nil {
+		t.Fatal("expected an error for a template referencing a nonexistent field, got nil")
+	}
+}
+
+func TestOutputMountsWithTemplateBadSyntax(t *testing.T) {
+	var buf bytes.Buffer
+	err := outputMountsWithTemplate(&buf, []jsonMount{{MountPoint: "/mnt"}}, "{{.MountPoint")
+	if err == nil {
+		t.Fatal("expected an error for an unparseable template, got nil")
+	}
+}
+
+func TestValidMountMode(t *testing.T) {
+	for _, mode := range []string{"ro", "rw"} {
+		if err := validMountMode(mode); err != nil {
+			t.Errorf("mode %q: unexpected error: %v", mode, err)
+		}
+	}
+	if err := validMountMode("bogus"); err == nil {
+		t.Error("expected an error for an invalid mode, got nil")
+	}
+}
+
+func TestSetMountPropagationInvalid(t *testing.T) {
+	if err := setMountPropagation("/does-not-matter", "bogus"); err == nil {
+		t.Error("expected an error for an invalid propagation, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	buildahcli "github.com/containers/buildah/pkg/cli"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	var (
+		umountDescription = `buildah umount
+  Unmounts the root file system of the specified working containers.
+`
+		umountAll bool
+	)
+	umountCommand := &cobra.Command{
+		Use:     "umount",
+		Aliases: []string{"unmount"},
+		Short:   "Unmount the root file system of the specified working containers",
+		Long:    umountDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return umountCmd(cmd, args, umountAll)
+		},
+		Example: `buildah umount containerID
+  buildah umount containerID1 containerID2 containerID3
+  buildah umount --all`,
+	}
+	umountCommand.SetUsageTemplate(UsageTemplate())
+
+	flags := umountCommand.Flags()
+	flags.SetInterspersed(false)
+	flags.BoolVarP(&umountAll, "all", "a", false, "umount all of the currently mounted containers")
+	rootCmd.AddCommand(umountCommand)
+}
+
+func umountCmd(c *cobra.Command, args []string, umountAll bool) error {
+	if err := buildahcli.VerifyFlagsArgsOrder(args); err != nil {
+		return err
+	}
+	if len(args) == 0 && !umountAll {
+		return errors.Errorf("container ID must be specified")
+	}
+	if len(args) > 0 && umountAll {
+		return errors.Errorf("when using the --all switch, you may not pass any container IDs")
+	}
+
+	store, err := getStore(c)
+	if err != nil {
+		return err
+	}
+
+	var lastError error
+	if umountAll {
+		builders, err := openBuilders(store)
+		if err != nil {
+			return errors.Wrapf(err, "error reading build containers")
+		}
+		for _, builder := range builders {
+			if err := unmountBuilder(store, builder); err != nil {
+				if lastError != nil {
+					fmt.Fprintln(os.Stderr, lastError)
+				}
+				lastError = err
+			}
+		}
+		return lastError
+	}
+
+	for _, name := range args {
+		builder, err := openBuilder(getContext(), store, name)
+		if err != nil {
+			if lastError != nil {
+				fmt.Fprintln(os.Stderr, lastError)
+			}
+			lastError = errors.Wrapf(err, "error reading build container %q", name)
+			continue
+		}
+		if err := unmountBuilder(store, builder); err != nil {
+			if lastError != nil {
+				fmt.Fprintln(os.Stderr, lastError)
+			}
+			lastError = err
+		}
+	}
+	return lastError
+}